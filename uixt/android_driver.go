@@ -0,0 +1,143 @@
+package uixt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/httprunner/httprunner/v5/pkg/gadb"
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// AndroidDriver implements IDriver for a single Android device, talking to
+// it over adb/gadb.
+type AndroidDriver struct {
+	device *gadb.Device
+	serial string
+}
+
+// StreamScreen pulls JPEG frames from the device via minicap running in its
+// default continuous-capture mode, throttles them to opts' FPS, and hands
+// each frame to opts' FrameHandler until ctx is canceled. minicap is the only
+// frame source; there is no fallback when it is unavailable on the device.
+func (d *AndroidDriver) StreamScreen(ctx context.Context, opts ...option.ActionOption) error {
+	options := option.NewActionOptions(opts...)
+	if options.FrameHandler == nil {
+		return fmt.Errorf("StreamScreen requires a FrameHandler")
+	}
+	fps := options.FPS
+	if fps <= 0 {
+		fps = 10
+	}
+
+	args := []string{"shell", "minicap"}
+	if options.MaxWidth > 0 {
+		projection, err := d.projection(options.MaxWidth)
+		if err != nil {
+			return fmt.Errorf("failed to determine screen size on %s: %v", d.serial, err)
+		}
+		args = append(args, "-P", projection)
+	}
+
+	cmd, stdout, err := d.device.RunShellCommandStreaming(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("failed to start minicap stream on %s: %v", d.serial, err)
+	}
+	defer cmd.Wait()
+
+	return readMJPEGFrames(ctx, bufio.NewReader(stdout), throttle(options.FrameHandler, fps))
+}
+
+// projection queries the device's real screen size and scales it
+// proportionally so the width is maxWidth, for minicap's -P flag
+// (e.g. "1080x2400@720x1600/0"). Passing maxWidth for both target dimensions
+// unconditionally would stretch the frame on any non-square device.
+func (d *AndroidDriver) projection(maxWidth int) (string, error) {
+	width, height, err := d.screenSize()
+	if err != nil {
+		return "", err
+	}
+
+	targetWidth, targetHeight := maxWidth, maxWidth*height/width
+	return fmt.Sprintf("%dx%d@%dx%d/0", width, height, targetWidth, targetHeight), nil
+}
+
+// screenSize parses the "Physical size: WxH" line out of `adb shell wm size`.
+func (d *AndroidDriver) screenSize() (width, height int, err error) {
+	output, err := d.device.RunShellCommand("wm", "size")
+	if err != nil {
+		return 0, 0, err
+	}
+	_, dims, ok := strings.Cut(strings.TrimSpace(output), ": ")
+	if !ok {
+		return 0, 0, fmt.Errorf("unexpected `wm size` output: %q", output)
+	}
+	w, h, ok := strings.Cut(dims, "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("unexpected `wm size` output: %q", output)
+	}
+	width, err = strconv.Atoi(w)
+	if err != nil {
+		return 0, 0, err
+	}
+	height, err = strconv.Atoi(h)
+	if err != nil {
+		return 0, 0, err
+	}
+	return width, height, nil
+}
+
+// throttle wraps handler so it fires at most fps times per second, dropping
+// any frames that arrive faster than that.
+func throttle(handler func(frame []byte), fps int) func([]byte) {
+	interval := time.Second / time.Duration(fps)
+	var last time.Time
+	return func(frame []byte) {
+		if now := time.Now(); last.IsZero() || now.Sub(last) >= interval {
+			last = now
+			handler(frame)
+		}
+	}
+}
+
+// readMJPEGFrames scans r for JPEG frames delimited by SOI (0xFFD8) / EOI
+// (0xFFD9) markers and invokes handler for each complete frame found before
+// ctx is canceled.
+func readMJPEGFrames(ctx context.Context, r *bufio.Reader, handler func([]byte)) error {
+	var frame []byte
+	inFrame := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if !inFrame {
+			if b == 0xFF {
+				next, err := r.Peek(1)
+				if err == nil && len(next) == 1 && next[0] == 0xD8 {
+					inFrame = true
+					frame = []byte{0xFF}
+				}
+			}
+			continue
+		}
+
+		frame = append(frame, b)
+		if len(frame) >= 2 && frame[len(frame)-2] == 0xFF && frame[len(frame)-1] == 0xD9 {
+			handler(frame)
+			frame = nil
+			inFrame = false
+		}
+	}
+}
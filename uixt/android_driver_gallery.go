@@ -0,0 +1,267 @@
+package uixt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// androidGalleryDir is where PushImage places assets and where ListImages /
+// PullImage read them back from.
+const androidGalleryDir = "/sdcard/DCIM/Camera"
+
+// androidImageExtensions is the set of file extensions ListImages treats as
+// gallery images; everything else in androidGalleryDir (subdirectories,
+// .nomedia, thumbnail caches, etc.) is skipped.
+var androidImageExtensions = map[string]bool{
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true,
+}
+
+// androidGalleryEntry is a (name, size, mtime) tuple for one file in
+// androidGalleryDir, produced by either of the listing strategies below.
+type androidGalleryEntry struct {
+	name  string
+	size  int64
+	mtime int64
+}
+
+// ListImages enumerates androidGalleryDir, preferring the single-round-trip
+// listGalleryFast and falling back to listGalleryFallback if that fails
+// (e.g. on a device whose `ls` doesn't understand --time-style).
+func (d *AndroidDriver) ListImages(opts option.ListImagesOptions) ([]option.ImageInfo, int, error) {
+	entries, err := d.listGalleryFast()
+	if err != nil {
+		log.Warn().Err(err).Str("serial", d.serial).
+			Msg("ls --time-style listing failed, falling back to per-file stat")
+		entries, err = d.listGalleryFallback()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var images []option.ImageInfo
+	for _, entry := range entries {
+		if !androidImageExtensions[strings.ToLower(filepath.Ext(entry.name))] {
+			continue
+		}
+		if opts.Since > 0 && entry.mtime <= opts.Since {
+			continue
+		}
+
+		image := option.ImageInfo{
+			Path:      androidGalleryDir + "/" + entry.name,
+			SizeBytes: entry.size,
+			MTime:     entry.mtime,
+		}
+		if opts.IncludeThumbnail {
+			thumbnail, err := d.thumbnailBase64(image.Path)
+			if err != nil {
+				log.Warn().Err(err).Str("path", image.Path).Msg("failed to generate thumbnail")
+			} else {
+				image.ThumbnailBase64 = thumbnail
+			}
+		}
+		if opts.IncludeHash {
+			hash, err := d.sha256Remote(image.Path)
+			if err != nil {
+				log.Warn().Err(err).Str("path", image.Path).Msg("failed to hash image")
+			} else {
+				image.Sha256 = hash
+			}
+		}
+		images = append(images, image)
+	}
+
+	sort.Slice(images, func(i, j int) bool { return images[i].MTime > images[j].MTime })
+
+	total := len(images)
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = total
+	}
+	start := opts.Offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	return images[start:end], total, nil
+}
+
+// listGalleryFast lists androidGalleryDir with a single `ls -la
+// --time-style=+%s` call, getting name/size/mtime in one round trip.
+// --time-style is a GNU coreutils option; toybox-based `ls` (shipped on some
+// Android builds) may reject it or ignore it and print dates in its default
+// format, either of which makes the size/mtime fields fail to parse as
+// integers here, so that case is treated as an error and left to the caller
+// to retry via listGalleryFallback.
+func (d *AndroidDriver) listGalleryFast() ([]androidGalleryEntry, error) {
+	output, err := d.device.RunShellCommand("ls", "-la", "--time-style=+%s", androidGalleryDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gallery directory on %s: %v", d.serial, err)
+	}
+
+	var entries []androidGalleryEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		// -rw-rw---- 1 root sdcard_rw 12345 1700000000 photo.jpg
+		if len(fields) < 6 || strings.HasPrefix(fields[0], "d") {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[len(fields)-3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected `ls --time-style` output on %s (--time-style may be unsupported): %q", d.serial, line)
+		}
+		mtime, err := strconv.ParseInt(fields[len(fields)-2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected `ls --time-style` output on %s (--time-style may be unsupported): %q", d.serial, line)
+		}
+		entries = append(entries, androidGalleryEntry{name: fields[len(fields)-1], size: size, mtime: mtime})
+	}
+	return entries, nil
+}
+
+// listGalleryFallback lists androidGalleryDir file names with a plain `ls
+// -1` (supported by every `ls` implementation Android ships) and stats each
+// image file individually via `stat -c "%s %Y"`, which toybox's `stat`
+// supports even on devices where listGalleryFast's `ls --time-style` option
+// doesn't work. This costs one extra round trip per file, so it's only used
+// when listGalleryFast fails.
+func (d *AndroidDriver) listGalleryFallback() ([]androidGalleryEntry, error) {
+	output, err := d.device.RunShellCommand("ls", "-1", androidGalleryDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gallery directory on %s: %v", d.serial, err)
+	}
+
+	var entries []androidGalleryEntry
+	for _, name := range strings.Split(output, "\n") {
+		name = strings.TrimSpace(name)
+		if name == "" || !androidImageExtensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+		remotePath := androidGalleryDir + "/" + name
+		statOutput, err := d.device.RunShellCommand("stat", "-c", "%s %Y", remotePath)
+		if err != nil {
+			log.Warn().Err(err).Str("path", remotePath).Msg("failed to stat gallery file, skipping")
+			continue
+		}
+		fields := strings.Fields(statOutput)
+		if len(fields) < 2 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		mtime, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, androidGalleryEntry{name: name, size: size, mtime: mtime})
+	}
+	return entries, nil
+}
+
+// PullImage copies remotePath off the device to localPath via `adb pull`.
+func (d *AndroidDriver) PullImage(remotePath, localPath string) error {
+	if err := d.device.Pull(remotePath, localPath); err != nil {
+		return fmt.Errorf("failed to pull %s from %s: %v", remotePath, d.serial, err)
+	}
+	return nil
+}
+
+// sha256Remote pulls remotePath to a local temp file and hashes its
+// contents, so list_images can report the same sha256 pull_image returns.
+func (d *AndroidDriver) sha256Remote(remotePath string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "gallery-hash-*")
+	if err != nil {
+		return "", err
+	}
+	tmpPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(tmpPath)
+
+	if err := d.PullImage(remotePath, tmpPath); err != nil {
+		return "", err
+	}
+	return sha256File(tmpPath)
+}
+
+// thumbnailMaxDimension bounds the longer edge of a thumbnail produced by
+// thumbnailBase64, so list_images responses with includeThumbnail stay
+// bounded regardless of the original image's resolution.
+const thumbnailMaxDimension = 200
+
+// thumbnailBase64 pulls remotePath to a local temp file, downscales it to
+// thumbnailMaxDimension, and returns the result base64-encoded.
+func (d *AndroidDriver) thumbnailBase64(remotePath string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "gallery-thumb-*")
+	if err != nil {
+		return "", err
+	}
+	tmpPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(tmpPath)
+
+	if err := d.PullImage(remotePath, tmpPath); err != nil {
+		return "", err
+	}
+
+	src, err := os.Open(tmpPath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s for thumbnail: %v", remotePath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, downscale(img, thumbnailMaxDimension), &jpeg.Options{Quality: 60}); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// downscale resizes img (nearest-neighbor) so its longer edge is at most
+// maxDimension, preserving aspect ratio. img is returned unchanged if it
+// already fits.
+func downscale(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return img
+	}
+
+	targetWidth, targetHeight := maxDimension, maxDimension*height/width
+	if height > width {
+		targetWidth, targetHeight = maxDimension*width/height, maxDimension
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y := 0; y < targetHeight; y++ {
+		for x := 0; x < targetWidth; x++ {
+			srcX := bounds.Min.X + x*width/targetWidth
+			srcY := bounds.Min.Y + y*height/targetHeight
+			dst.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return dst
+}
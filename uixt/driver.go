@@ -0,0 +1,37 @@
+package uixt
+
+import (
+	"context"
+
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// Device is the minimal device identity surface shared by Android and iOS
+// devices, used wherever a tool only needs to know which device it's
+// talking to.
+type Device interface {
+	UUID() string
+}
+
+// IDriver abstracts the operations available on a connected Android or iOS
+// device. AndroidDriver and IOSDriver implement it.
+type IDriver interface {
+	GetDevice() Device
+
+	ScreenRecord(opts ...option.ActionOption) (videoPath string, err error)
+	PushImage(imagePath string) error
+	ClearImages() error
+
+	// StreamScreen pulls frames from the device and invokes the
+	// option.WithFrameHandler callback for each one until ctx is canceled.
+	StreamScreen(ctx context.Context, opts ...option.ActionOption) error
+
+	// ListImages enumerates the device's gallery directory, the counterpart
+	// to PushImage/ClearImages. It returns the page of images selected by
+	// opts along with the total count before pagination.
+	ListImages(opts option.ListImagesOptions) (images []option.ImageInfo, total int, err error)
+
+	// PullImage copies remotePath (as returned by ListImages) off the
+	// device to localPath.
+	PullImage(remotePath, localPath string) error
+}
@@ -1,6 +1,8 @@
 package uixt
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,32 +13,33 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// DetectAndRenameImageFile examines the file content to determine its image type
-// and renames the file with the appropriate extension (.jpg, .png, etc.)
-func DetectAndRenameImageFile(filePath string) (string, error) {
-	// Open the file
+// detectFileContentType reads the first 512 bytes of filePath and returns
+// its sniffed HTTP content type, without consuming the file.
+func detectFileContentType(filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to open file for type detection: %v", err)
 	}
 	defer file.Close()
 
-	// Read the first 512 bytes to detect content type
 	buffer := make([]byte, 512)
 	_, err = file.Read(buffer)
 	if err != nil && err != io.EOF {
 		return "", fmt.Errorf("failed to read file for type detection: %v", err)
 	}
 
-	// Reset file pointer
-	_, err = file.Seek(0, 0)
-	if err != nil {
-		return "", fmt.Errorf("failed to reset file pointer: %v", err)
-	}
-
-	// Detect content type
 	contentType := http.DetectContentType(buffer)
 	log.Info().Str("filePath", filePath).Str("contentType", contentType).Msg("Detected content type")
+	return contentType, nil
+}
+
+// DetectAndRenameImageFile examines the file content to determine its image type
+// and renames the file with the appropriate extension (.jpg, .png, etc.)
+func DetectAndRenameImageFile(filePath string) (string, error) {
+	contentType, err := detectFileContentType(filePath)
+	if err != nil {
+		return "", err
+	}
 
 	// Determine file extension based on content type
 	var extension string
@@ -83,3 +86,19 @@ func DetectAndRenameImageFile(filePath string) (string, error) {
 	log.Info().Str("oldPath", filePath).Str("newPath", newFilePath).Msg("Renamed image file with proper extension")
 	return newFilePath, nil
 }
+
+// sha256File returns the hex-encoded SHA-256 digest of a file's contents.
+func sha256File(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for hashing: %v", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("failed to hash file: %v", err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,58 @@
+package uixt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// IOSDriver implements IDriver for a single iOS device, talking to it over
+// WebDriverAgent.
+type IOSDriver struct {
+	udid   string
+	wdaURL string // base URL of the WebDriverAgent instance forwarded for this device
+}
+
+// StreamScreen proxies WebDriverAgent's MJPEG screenshot stream
+// (mjpegServer, typically forwarded to a local port per device), asking WDA
+// to cap the rate/width at the source via query params and throttling
+// client-side as a backstop, then hands each decoded JPEG frame to opts'
+// FrameHandler until ctx is canceled.
+func (d *IOSDriver) StreamScreen(ctx context.Context, opts ...option.ActionOption) error {
+	options := option.NewActionOptions(opts...)
+	if options.FrameHandler == nil {
+		return fmt.Errorf("StreamScreen requires a FrameHandler")
+	}
+	fps := options.FPS
+	if fps <= 0 {
+		fps = 10
+	}
+
+	query := url.Values{"fps": {strconv.Itoa(fps)}}
+	if options.MaxWidth > 0 {
+		query.Set("maxWidth", strconv.Itoa(options.MaxWidth))
+	}
+	mjpegURL := d.wdaURL + "/mjpeg?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mjpegURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build WDA mjpeg request for %s: %v", d.udid, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to WDA mjpeg endpoint on %s: %v", d.udid, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("WDA mjpeg endpoint on %s returned status %d", d.udid, resp.StatusCode)
+	}
+
+	return readMJPEGFrames(ctx, bufio.NewReader(resp.Body), throttle(options.FrameHandler, fps))
+}
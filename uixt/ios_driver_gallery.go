@@ -0,0 +1,153 @@
+package uixt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// iosPhotosAppID is the bundle ID whose house-arrest AFC connection exposes
+// the album PushImage writes into.
+const iosPhotosAppID = "com.apple.mobileslideshow"
+
+// afcClient is the subset of the go-ios AFC/house-arrest client this file
+// needs, satisfied by the connection PushImage already opens against
+// iosPhotosAppID.
+type afcClient interface {
+	ReadDir(path string) ([]string, error)
+	Stat(path string) (size int64, mtime int64, err error)
+	Pull(remotePath string, w io.Writer) error
+}
+
+// afcConn returns the AFC connection used to reach the photo album, shared
+// with PushImage/ClearImages.
+func (d *IOSDriver) afcConn() (afcClient, error) {
+	return newHouseArrestClient(d.udid, iosPhotosAppID)
+}
+
+// ListImages enumerates the album PushImage populates, via the same
+// AFC/house-arrest channel used to push images onto the device.
+func (d *IOSDriver) ListImages(opts option.ListImagesOptions) ([]option.ImageInfo, int, error) {
+	client, err := d.afcConn()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open AFC connection to %s: %v", d.udid, err)
+	}
+
+	entries, err := client.ReadDir("/")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list photo album on %s: %v", d.udid, err)
+	}
+
+	var images []option.ImageInfo
+	for _, name := range entries {
+		size, mtime, err := client.Stat(name)
+		if err != nil {
+			continue
+		}
+		if opts.Since > 0 && mtime <= opts.Since {
+			continue
+		}
+
+		image := option.ImageInfo{Path: name, SizeBytes: size, MTime: mtime}
+		if opts.IncludeThumbnail {
+			thumbnail, err := d.thumbnailBase64(client, name)
+			if err != nil {
+				log.Warn().Err(err).Str("path", name).Msg("failed to generate thumbnail")
+			} else {
+				image.ThumbnailBase64 = thumbnail
+			}
+		}
+		if opts.IncludeHash {
+			hash, err := sha256Remote(client, name)
+			if err != nil {
+				log.Warn().Err(err).Str("path", name).Msg("failed to hash image")
+			} else {
+				image.Sha256 = hash
+			}
+		}
+		images = append(images, image)
+	}
+
+	sort.Slice(images, func(i, j int) bool { return images[i].MTime > images[j].MTime })
+
+	total := len(images)
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = total
+	}
+	start := opts.Offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	return images[start:end], total, nil
+}
+
+// PullImage copies remotePath out of the photo album to localPath via AFC.
+func (d *IOSDriver) PullImage(remotePath, localPath string) error {
+	client, err := d.afcConn()
+	if err != nil {
+		return fmt.Errorf("failed to open AFC connection to %s: %v", d.udid, err)
+	}
+
+	f, err := os.Create(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to create local file %s: %v", localPath, err)
+	}
+	defer f.Close()
+
+	if err := client.Pull(path.Base(remotePath), f); err != nil {
+		return fmt.Errorf("failed to pull %s from %s: %v", remotePath, d.udid, err)
+	}
+	return nil
+}
+
+// thumbnailBase64 pulls remotePath into memory over AFC, downscales it to
+// thumbnailMaxDimension, and returns the result base64-encoded. The album
+// only ever contains images PushImage wrote (jpg/png), so image.Decode's
+// registered formats are enough without pulling to a temp file first.
+func (d *IOSDriver) thumbnailBase64(client afcClient, remotePath string) (string, error) {
+	var buf bytes.Buffer
+	if err := client.Pull(remotePath, &buf); err != nil {
+		return "", fmt.Errorf("failed to pull %s for thumbnail: %v", remotePath, err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s for thumbnail: %v", remotePath, err)
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, downscale(img, thumbnailMaxDimension), &jpeg.Options{Quality: 60}); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(out.Bytes()), nil
+}
+
+// sha256Remote pulls remotePath into memory over AFC and hashes its
+// contents, so list_images can report the same sha256 pull_image returns.
+func sha256Remote(client afcClient, remotePath string) (string, error) {
+	var buf bytes.Buffer
+	if err := client.Pull(remotePath, &buf); err != nil {
+		return "", fmt.Errorf("failed to pull %s for hashing: %v", remotePath, err)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,228 @@
+package uixt
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// JobStatus represents the lifecycle state of an async job.
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCanceled  JobStatus = "canceled"
+)
+
+// Job tracks the state of a single long-running MCP operation, such as a
+// screen recording started via start_screen_record. Jobs are created by
+// JobManager.NewJob and transition through JobStatus until they reach a
+// terminal state. All mutable fields are guarded by mu since the worker
+// goroutine writes them concurrently with get_job_status/cancel_job reads -
+// callers outside this file must go through Snapshot rather than reading
+// fields directly.
+type Job struct {
+	ID string `json:"id"`
+
+	mu        sync.Mutex
+	status    JobStatus
+	startedAt time.Time
+	endedAt   time.Time
+	errMsg    string
+	result    interface{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// JobSnapshot is an immutable, race-free copy of a Job's state at a point in
+// time, safe to read after it has been returned by Job.Snapshot.
+type JobSnapshot struct {
+	ID        string      `json:"id"`
+	Status    JobStatus   `json:"status"`
+	StartedAt time.Time   `json:"startedAt"`
+	EndedAt   time.Time   `json:"endedAt,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Result    interface{} `json:"result,omitempty"`
+}
+
+// Snapshot returns a copy of the job's current state under lock.
+func (j *Job) Snapshot() JobSnapshot {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return JobSnapshot{
+		ID:        j.ID,
+		Status:    j.status,
+		StartedAt: j.startedAt,
+		EndedAt:   j.endedAt,
+		Error:     j.errMsg,
+		Result:    j.result,
+	}
+}
+
+func (j *Job) setStatus(status JobStatus) {
+	j.mu.Lock()
+	j.status = status
+	j.mu.Unlock()
+}
+
+// JobManager assigns a UUID to every long-running operation and tracks its
+// state so MCP clients can poll or cancel it without holding the original
+// request open. Active jobs are kept in a sync.Map and a background reaper
+// periodically evicts terminal jobs older than ttl, which GetJobManager sets
+// to defaultJobTTL or jobTTLEnvVar's value if set.
+type JobManager struct {
+	jobs sync.Map // map[string]*Job
+	ttl  time.Duration
+}
+
+// defaultJobTTL is how long a terminal job's state remains queryable via
+// get_job_status before the reaper evicts it, unless overridden via
+// jobTTLEnvVar.
+const defaultJobTTL = 10 * time.Minute
+
+// jobTTLEnvVar, when set to a Go duration string (e.g. "30m"), overrides
+// defaultJobTTL for the process-wide JobManager returned by GetJobManager.
+const jobTTLEnvVar = "HTTPRUNNER_JOB_TTL"
+
+var (
+	globalJobManager     *JobManager
+	globalJobManagerOnce sync.Once
+)
+
+// GetJobManager returns the process-wide JobManager, starting its reaper on
+// first use with a TTL of defaultJobTTL, or jobTTLEnvVar's value if set.
+func GetJobManager() *JobManager {
+	globalJobManagerOnce.Do(func() {
+		globalJobManager = NewJobManager(jobTTLFromEnv())
+	})
+	return globalJobManager
+}
+
+// jobTTLFromEnv parses jobTTLEnvVar, falling back to defaultJobTTL if it is
+// unset or invalid.
+func jobTTLFromEnv() time.Duration {
+	raw := os.Getenv(jobTTLEnvVar)
+	if raw == "" {
+		return defaultJobTTL
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl <= 0 {
+		log.Warn().Str("env", jobTTLEnvVar).Str("value", raw).
+			Msg("invalid job TTL override, using default")
+		return defaultJobTTL
+	}
+	return ttl
+}
+
+// NewJobManager creates a JobManager whose reaper evicts terminal jobs older
+// than ttl.
+func NewJobManager(ttl time.Duration) *JobManager {
+	m := &JobManager{ttl: ttl}
+	go m.reapLoop()
+	return m
+}
+
+// NewJob registers a pending job and returns it along with a context that
+// callers should run their work with. The job is expected to outlive the
+// MCP call that started it, so the returned context is deliberately detached
+// from any per-call request context (which the MCP server may cancel as
+// soon as the handler returns) and rooted in context.Background instead;
+// canceling the job calls the returned context's cancel func.
+func (m *JobManager) NewJob() (*Job, context.Context) {
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &Job{
+		ID:        uuid.NewString(),
+		status:    JobStatusPending,
+		startedAt: time.Now(),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	m.jobs.Store(job.ID, job)
+	return job, jobCtx
+}
+
+// MarkRunning transitions a job from pending to running.
+func (m *JobManager) MarkRunning(job *Job) {
+	job.setStatus(JobStatusRunning)
+}
+
+// Finish records the terminal outcome of a job. err nil means success.
+func (m *JobManager) Finish(job *Job, result interface{}, err error) {
+	job.mu.Lock()
+	job.endedAt = time.Now()
+	switch {
+	case err == context.Canceled:
+		job.status = JobStatusCanceled
+	case err != nil:
+		job.status = JobStatusFailed
+		job.errMsg = err.Error()
+	default:
+		job.status = JobStatusSucceeded
+		job.result = result
+	}
+	job.mu.Unlock()
+	close(job.done)
+}
+
+// Get returns the job with the given id, if it is still tracked.
+func (m *JobManager) Get(id string) (*Job, bool) {
+	v, ok := m.jobs.Load(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*Job), true
+}
+
+// Cancel invokes the job's cancel func and blocks until the underlying
+// goroutine has unwound (i.e. Finish has been called), so callers can be
+// sure any in-flight file (e.g. a video recording) has been finalized.
+func (m *JobManager) Cancel(id string) (*Job, error) {
+	job, ok := m.Get(id)
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	if isTerminal(job.Snapshot().Status) {
+		return job, nil
+	}
+	job.cancel()
+	<-job.done
+	return job, nil
+}
+
+// reapLoop periodically evicts terminal jobs older than m.ttl.
+func (m *JobManager) reapLoop() {
+	ticker := time.NewTicker(m.ttl / 2)
+	defer ticker.Stop()
+	for range ticker.C {
+		now := time.Now()
+		m.jobs.Range(func(key, value interface{}) bool {
+			job := value.(*Job)
+			snapshot := job.Snapshot()
+			if isTerminal(snapshot.Status) && now.Sub(snapshot.EndedAt) > m.ttl {
+				m.jobs.Delete(key)
+				log.Debug().Str("jobId", job.ID).Msg("reaped expired job")
+			}
+			return true
+		})
+	}
+}
+
+func isTerminal(status JobStatus) bool {
+	return status == JobStatusSucceeded || status == JobStatusFailed || status == JobStatusCanceled
+}
+
+// ErrJobNotFound is returned by JobManager.Cancel when the job id is unknown
+// or has already been reaped.
+var ErrJobNotFound = jobNotFoundError{}
+
+type jobNotFoundError struct{}
+
+func (jobNotFoundError) Error() string { return "job not found" }
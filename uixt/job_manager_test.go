@@ -0,0 +1,126 @@
+package uixt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobManagerLifecycle(t *testing.T) {
+	jm := NewJobManager(time.Minute)
+
+	job, ctx := jm.NewJob()
+	if got := job.Snapshot().Status; got != JobStatusPending {
+		t.Fatalf("expected pending status, got %s", got)
+	}
+
+	jm.MarkRunning(job)
+	if got := job.Snapshot().Status; got != JobStatusRunning {
+		t.Fatalf("expected running status, got %s", got)
+	}
+
+	jm.Finish(job, "ok", nil)
+	snapshot := job.Snapshot()
+	if snapshot.Status != JobStatusSucceeded {
+		t.Fatalf("expected succeeded status, got %s", snapshot.Status)
+	}
+	if snapshot.Result != "ok" {
+		t.Fatalf("expected result %q, got %v", "ok", snapshot.Result)
+	}
+
+	if ctx.Err() != nil {
+		t.Fatalf("context should not be canceled on success, got %v", ctx.Err())
+	}
+}
+
+func TestJobManagerFinishWithError(t *testing.T) {
+	jm := NewJobManager(time.Minute)
+	job, _ := jm.NewJob()
+
+	jm.Finish(job, nil, errors.New("boom"))
+	snapshot := job.Snapshot()
+	if snapshot.Status != JobStatusFailed {
+		t.Fatalf("expected failed status, got %s", snapshot.Status)
+	}
+	if snapshot.Error != "boom" {
+		t.Fatalf("expected error %q, got %q", "boom", snapshot.Error)
+	}
+}
+
+func TestJobManagerCancelWaitsForDone(t *testing.T) {
+	jm := NewJobManager(time.Minute)
+	job, ctx := jm.NewJob()
+	jm.MarkRunning(job)
+
+	finished := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		jm.Finish(job, nil, ctx.Err())
+		close(finished)
+	}()
+
+	if _, err := jm.Cancel(job.ID); err != nil {
+		t.Fatalf("unexpected error canceling job: %v", err)
+	}
+
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Cancel returned before the worker goroutine finished")
+	}
+
+	if got := job.Snapshot().Status; got != JobStatusCanceled {
+		t.Fatalf("expected canceled status, got %s", got)
+	}
+}
+
+func TestJobManagerCancelUnknownJob(t *testing.T) {
+	jm := NewJobManager(time.Minute)
+	if _, err := jm.Cancel("does-not-exist"); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestJobTTLFromEnv(t *testing.T) {
+	t.Setenv(jobTTLEnvVar, "")
+	if got := jobTTLFromEnv(); got != defaultJobTTL {
+		t.Fatalf("expected default TTL %v when unset, got %v", defaultJobTTL, got)
+	}
+
+	t.Setenv(jobTTLEnvVar, "45m")
+	if got := jobTTLFromEnv(); got != 45*time.Minute {
+		t.Fatalf("expected 45m TTL override, got %v", got)
+	}
+
+	t.Setenv(jobTTLEnvVar, "not-a-duration")
+	if got := jobTTLFromEnv(); got != defaultJobTTL {
+		t.Fatalf("expected default TTL for invalid override, got %v", got)
+	}
+}
+
+// TestJobManagerSurvivesCallerContext guards against deriving a job's
+// context from the inbound MCP call's per-request ctx: the job must keep
+// running (and its context must stay live) even after the context the
+// caller happened to start it from has been canceled.
+func TestJobManagerSurvivesCallerContext(t *testing.T) {
+	jm := NewJobManager(time.Minute)
+
+	callerCtx, cancelCaller := context.WithCancel(context.Background())
+	job, jobCtx := jm.NewJob()
+	jm.MarkRunning(job)
+	cancelCaller()
+	_ = callerCtx
+
+	select {
+	case <-jobCtx.Done():
+		t.Fatal("job context was canceled when the originating call's context was canceled")
+	default:
+	}
+
+	jm.Finish(job, "ok", nil)
+	if got := job.Snapshot().Status; got != JobStatusSucceeded {
+		t.Fatalf("expected succeeded status, got %s", got)
+	}
+}
@@ -0,0 +1,382 @@
+package uixt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/danielpaulus/go-ios/ios"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+
+	"github.com/httprunner/httprunner/v5/pkg/gadb"
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// defaultBatchConcurrency bounds how many devices are driven in parallel by
+// the batch tools below when maxConcurrency is not specified.
+const defaultBatchConcurrency = 4
+
+// batchDevice is one fan-out target resolved by resolveBatchSerials. With
+// all=true, Platform is the platform the serial was discovered under and the
+// top-level "platform" argument is ignored; for caller-supplied serials it is
+// the top-level "platform" argument (empty if none was given).
+type batchDevice struct {
+	Serial   string
+	Platform string
+}
+
+// resolveBatchSerials expands the "serials" / "all" arguments into the
+// concrete set of devices to fan out over. With all=true this mirrors
+// ToolListAvailableDevices and unions Android and iOS serials, tagging each
+// with the platform it was discovered under.
+func resolveBatchSerials(ctx context.Context, arguments map[string]interface{}) ([]batchDevice, error) {
+	if all, _ := arguments["all"].(bool); all {
+		var devices []batchDevice
+		if client, err := gadb.NewClient(); err == nil {
+			if androidDevices, err := client.DeviceList(); err == nil {
+				for _, device := range androidDevices {
+					devices = append(devices, batchDevice{Serial: device.Serial(), Platform: "android"})
+				}
+			}
+		}
+		if iosDevices, err := ios.ListDevices(); err == nil {
+			for _, dev := range iosDevices.DeviceList {
+				devices = append(devices, batchDevice{Serial: dev.Properties.SerialNumber, Platform: "ios"})
+			}
+		}
+		if len(devices) == 0 {
+			return nil, fmt.Errorf("no available devices found for all=true")
+		}
+		return devices, nil
+	}
+
+	raw, ok := arguments["serials"].([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil, fmt.Errorf("serials is required (or set all=true)")
+	}
+	// The caller knows which platform these serials belong to (if it
+	// matters), so the single top-level "platform" argument is trusted here,
+	// unlike in the all=true branch above where it would apply indiscriminately.
+	platform, _ := arguments["platform"].(string)
+	devices := make([]batchDevice, 0, len(raw))
+	for _, s := range raw {
+		if serial, ok := s.(string); ok && serial != "" {
+			devices = append(devices, batchDevice{Serial: serial, Platform: platform})
+		}
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("serials must be a non-empty list of device serial numbers")
+	}
+	return devices, nil
+}
+
+func batchConcurrency(arguments map[string]interface{}) int {
+	if v, ok := arguments["maxConcurrency"].(float64); ok && v > 0 {
+		return int(v)
+	}
+	return defaultBatchConcurrency
+}
+
+// PushImageResult is the per-device outcome of ToolPushImageBatch.
+type PushImageResult struct {
+	Serial    string `json:"serial"`
+	Platform  string `json:"platform,omitempty"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	ImagePath string `json:"imagePath,omitempty"`
+}
+
+// ToolPushImageBatch implements the push_image_batch tool call.
+type ToolPushImageBatch struct {
+	// Return data fields - these define the structure of data returned by this tool
+	Results   []PushImageResult `json:"results" desc:"Per-device push results"`
+	Succeeded int               `json:"succeeded" desc:"Number of devices the image was pushed to successfully"`
+	Failed    int               `json:"failed" desc:"Number of devices the push failed on"`
+}
+
+func (t *ToolPushImageBatch) Name() option.ActionName {
+	return option.ACTION_PushImageBatch
+}
+
+func (t *ToolPushImageBatch) Description() string {
+	return "Push an image to multiple devices concurrently. Accepts either a list of serials or all=true to target every available device. The image is downloaded once and reused across all devices. Partial failures do not fail the whole call. Canceling the request stops devices still waiting for a worker slot, but a push already in progress on a device runs to completion."
+}
+
+func (t *ToolPushImageBatch) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("platform", mcp.Enum("android", "ios"), mcp.Description("The platform type of the targeted devices")),
+		mcp.WithArray("serials", mcp.Description("Device serial numbers or UDIDs to push the image to")),
+		mcp.WithBoolean("all", mcp.Description("Push to every available device instead of specifying serials")),
+		mcp.WithNumber("maxConcurrency", mcp.Description("Maximum number of devices to push to in parallel. Default: 4")),
+		mcp.WithString("imagePath", mcp.Description("Path to the local image file to push to the devices")),
+		mcp.WithString("imageUrl", mcp.Description("URL of the image to download once and push to the devices")),
+		mcp.WithBoolean("cleanup", mcp.Description("Whether to delete the downloaded file after the last device finishes. Files kept in the download cache (useCache) are not deleted")),
+		mcp.WithBoolean("clearBefore", mcp.Description("Whether to clear images on each device before pushing")),
+		mcp.WithBoolean("useCache", mcp.Description("Whether to reuse a previously downloaded copy of imageUrl instead of re-fetching it. Default: true")),
+	}
+}
+
+func (t *ToolPushImageBatch) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments := request.GetArguments()
+
+		devices, err := resolveBatchSerials(ctx, arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		imagePath, hasPath := arguments["imagePath"].(string)
+		imageUrl, hasUrl := arguments["imageUrl"].(string)
+		cleanup, _ := arguments["cleanup"].(bool)
+		clearBefore, _ := arguments["clearBefore"].(bool)
+
+		if (!hasPath || imagePath == "") && (!hasUrl || imageUrl == "") {
+			return nil, fmt.Errorf("either imagePath or imageUrl is required")
+		}
+
+		// Download once (via the url cache, so concurrent batches of the
+		// same URL collapse into a single fetch) and reuse imagePath across
+		// every device in the batch.
+		downloadedFile := false
+		if hasUrl && imageUrl != "" {
+			useCache := true
+			if v, ok := arguments["useCache"].(bool); ok {
+				useCache = v
+			}
+			resolvedPath, err := GetOrDownloadImage(imageUrl, useCache, 0)
+			if err != nil {
+				return nil, err
+			}
+			imagePath = resolvedPath
+			downloadedFile = true
+		}
+		if downloadedFile && cleanup && !isCachedPath(imagePath) {
+			defer os.Remove(imagePath)
+		}
+
+		results := make([]PushImageResult, len(devices))
+		sem := make(chan struct{}, batchConcurrency(arguments))
+		var wg sync.WaitGroup
+
+		for i, device := range devices {
+			wg.Add(1)
+			go func(i int, device batchDevice) {
+				defer wg.Done()
+
+				// ctx is only observed here, while a worker is still queued
+				// on sem. Once past this select, PushImage doesn't take a
+				// ctx and can't be interrupted mid-transfer, so a canceled
+				// request stops picking up new devices but lets in-flight
+				// pushes run to completion.
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					results[i] = PushImageResult{Serial: device.Serial, Success: false, Error: ctx.Err().Error()}
+					return
+				}
+
+				result := PushImageResult{Serial: device.Serial, Platform: device.Platform, ImagePath: imagePath}
+
+				deviceArgs := map[string]interface{}{"serial": device.Serial}
+				if device.Platform != "" {
+					deviceArgs["platform"] = device.Platform
+				}
+				driverExt, err := setupXTDriver(ctx, deviceArgs)
+				if err != nil {
+					result.Error = err.Error()
+					results[i] = result
+					return
+				}
+
+				if clearBefore {
+					if err := driverExt.IDriver.ClearImages(); err != nil {
+						log.Warn().Err(err).Str("serial", device.Serial).Msg("Failed to clear images before batch push, continuing anyway")
+					}
+				}
+
+				if err := driverExt.IDriver.PushImage(imagePath); err != nil {
+					result.Error = err.Error()
+					results[i] = result
+					return
+				}
+
+				result.Success = true
+				results[i] = result
+			}(i, device)
+		}
+		wg.Wait()
+
+		succeeded, failed := 0, 0
+		for _, r := range results {
+			if r.Success {
+				succeeded++
+			} else {
+				failed++
+			}
+		}
+
+		message := fmt.Sprintf("Pushed image to %d/%d devices (%d failed)", succeeded, len(devices), failed)
+		returnData := ToolPushImageBatch{Results: results, Succeeded: succeeded, Failed: failed}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolPushImageBatch) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	return BuildMCPCallToolRequest(t.Name(), map[string]any{}, action), nil
+}
+
+// ScreenRecordResult is the per-device outcome of ToolScreenRecordBatch.
+type ScreenRecordResult struct {
+	Serial    string  `json:"serial"`
+	Platform  string  `json:"platform,omitempty"`
+	Success   bool    `json:"success"`
+	Error     string  `json:"error,omitempty"`
+	VideoPath string  `json:"videoPath,omitempty"`
+	Duration  float64 `json:"duration,omitempty"`
+}
+
+// ToolScreenRecordBatch implements the screen_record_batch tool call.
+type ToolScreenRecordBatch struct {
+	// Return data fields - these define the structure of data returned by this tool
+	Results   []ScreenRecordResult `json:"results" desc:"Per-device recording results"`
+	Succeeded int                  `json:"succeeded" desc:"Number of devices that recorded successfully"`
+	Failed    int                  `json:"failed" desc:"Number of devices the recording failed on"`
+}
+
+func (t *ToolScreenRecordBatch) Name() option.ActionName {
+	return option.ACTION_ScreenRecordBatch
+}
+
+func (t *ToolScreenRecordBatch) Description() string {
+	return "Record the screen of multiple devices concurrently. Accepts either a list of serials or all=true to target every available device. Partial failures do not fail the whole call."
+}
+
+func (t *ToolScreenRecordBatch) Options() []mcp.ToolOption {
+	options := []mcp.ToolOption{
+		mcp.WithString("platform", mcp.Enum("android", "ios"), mcp.Description("The platform type of the targeted devices")),
+		mcp.WithArray("serials", mcp.Description("Device serial numbers or UDIDs to record")),
+		mcp.WithBoolean("all", mcp.Description("Record every available device instead of specifying serials")),
+		mcp.WithNumber("maxConcurrency", mcp.Description("Maximum number of devices to record in parallel. Default: 4")),
+	}
+	return append(options, (&ToolScreenRecord{}).Options()[2:]...)
+}
+
+// namespaceScreenRecordPath suffixes a shared screenRecordPath with the
+// device serial so every device in the batch writes to its own file instead
+// of racing to overwrite a single path.
+func namespaceScreenRecordPath(path, serial string) string {
+	if path == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	return fmt.Sprintf("%s_%s%s", base, serial, ext)
+}
+
+func (t *ToolScreenRecordBatch) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments := request.GetArguments()
+
+		devices, err := resolveBatchSerials(ctx, arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		var opts []option.ActionOption
+		if duration, ok := arguments["duration"].(float64); ok && duration > 0 {
+			opts = append(opts, option.WithDuration(duration))
+		}
+		if audio, ok := arguments["screenRecordWithAudio"].(bool); ok && audio {
+			opts = append(opts, option.WithScreenRecordAudio(true))
+		}
+		if scrcpy, ok := arguments["screenRecordWithScrcpy"].(bool); ok && scrcpy {
+			opts = append(opts, option.WithScreenRecordScrcpy(true))
+		}
+		screenRecordPath, _ := arguments["screenRecordPath"].(string)
+
+		results := make([]ScreenRecordResult, len(devices))
+		sem := make(chan struct{}, batchConcurrency(arguments))
+		var wg sync.WaitGroup
+
+		for i, device := range devices {
+			wg.Add(1)
+			go func(i int, device batchDevice) {
+				defer wg.Done()
+
+				// As in ToolPushImageBatch, ctx is only guaranteed to stop a
+				// worker still queued on sem here. deviceOpts below does
+				// thread ctx into ScreenRecord via option.WithContext, so an
+				// in-flight recording may stop early if the underlying
+				// driver honors it, but that isn't guaranteed by this tool.
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					results[i] = ScreenRecordResult{Serial: device.Serial, Success: false, Error: ctx.Err().Error()}
+					return
+				}
+
+				result := ScreenRecordResult{Serial: device.Serial, Platform: device.Platform}
+
+				deviceArgs := map[string]interface{}{"serial": device.Serial}
+				if device.Platform != "" {
+					deviceArgs["platform"] = device.Platform
+				}
+				driverExt, err := setupXTDriver(ctx, deviceArgs)
+				if err != nil {
+					result.Error = err.Error()
+					results[i] = result
+					return
+				}
+
+				deviceOpts := append(append([]option.ActionOption{}, opts...), option.WithContext(ctx))
+				if path := namespaceScreenRecordPath(screenRecordPath, device.Serial); path != "" {
+					deviceOpts = append(deviceOpts, option.WithScreenRecordPath(path))
+				}
+				videoPath, err := driverExt.IDriver.ScreenRecord(deviceOpts...)
+				if err != nil {
+					result.Error = err.Error()
+					results[i] = result
+					return
+				}
+
+				options := option.NewActionOptions(deviceOpts...)
+				duration := options.Duration
+				if options.ScreenRecordDuration > 0 {
+					duration = options.ScreenRecordDuration
+				}
+
+				result.Success = true
+				result.VideoPath = videoPath
+				result.Duration = duration
+				results[i] = result
+			}(i, device)
+		}
+		wg.Wait()
+
+		succeeded, failed := 0, 0
+		for _, r := range results {
+			if r.Success {
+				succeeded++
+			} else {
+				failed++
+			}
+		}
+
+		message := fmt.Sprintf("Recorded screen on %d/%d devices (%d failed)", succeeded, len(devices), failed)
+		returnData := ToolScreenRecordBatch{Results: results, Succeeded: succeeded, Failed: failed}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolScreenRecordBatch) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	return BuildMCPCallToolRequest(t.Name(), map[string]any{}, action), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/danielpaulus/go-ios/ios"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -242,6 +243,8 @@ func (t *ToolPushImage) Options() []mcp.ToolOption {
 		mcp.WithString("imageUrl", mcp.Description("URL of the image to download and push to the device")),
 		mcp.WithBoolean("cleanup", mcp.Description("Whether to delete the downloaded file after pushing it to the device")),
 		mcp.WithBoolean("clearBefore", mcp.Description("Whether to clear images before pushing (if applicable)")),
+		mcp.WithBoolean("useCache", mcp.Description("Whether to reuse a previously downloaded copy of imageUrl instead of re-fetching it. Default: true. A cached download is kept even when cleanup is set")),
+		mcp.WithNumber("cacheTTL", mcp.Description("Seconds after which a cached download is considered stale and re-fetched. Default: no expiry")),
 	}
 }
 
@@ -263,23 +266,24 @@ func (t *ToolPushImage) Implement() server.ToolHandlerFunc {
 			return nil, fmt.Errorf("either imagePath or imageUrl is required")
 		}
 
-		// If we have a URL, download it
+		// If we have a URL, resolve it via the URL-keyed download cache so
+		// repeated pushes of the same asset skip the HTTP fetch entirely.
 		downloadedFile := false
 		if hasUrl && imageUrl != "" {
-			log.Info().Str("imageUrl", imageUrl).Msg("Downloading image from URL")
-			downloadedPath, err := DownloadFileByUrl(imageUrl)
-			if err != nil {
-				return nil, fmt.Errorf("failed to download image from URL: %v", err)
+			useCache := true
+			if v, ok := request.GetArguments()["useCache"].(bool); ok {
+				useCache = v
+			}
+			var cacheTTL time.Duration
+			if v, ok := request.GetArguments()["cacheTTL"].(float64); ok && v > 0 {
+				cacheTTL = time.Duration(v) * time.Second
 			}
 
-			// Detect image type and rename with proper extension
-			renamedPath, err := DetectAndRenameImageFile(downloadedPath)
+			resolvedPath, err := GetOrDownloadImage(imageUrl, useCache, cacheTTL)
 			if err != nil {
-				log.Warn().Err(err).Str("path", downloadedPath).Msg("Failed to detect image type or rename file, using original file")
-				imagePath = downloadedPath
-			} else {
-				imagePath = renamedPath
+				return nil, err
 			}
+			imagePath = resolvedPath
 			downloadedFile = true
 		}
 
@@ -298,15 +302,19 @@ func (t *ToolPushImage) Implement() server.ToolHandlerFunc {
 		// Push the image to the device
 		err = driverExt.IDriver.PushImage(imagePath)
 		if err != nil {
-			// If we downloaded the file and failed to push it, clean up
-			if downloadedFile && cleanup {
+			// If we downloaded the file and failed to push it, clean up -
+			// unless it lives in the url cache, where it should survive for
+			// reuse by the next request.
+			if downloadedFile && cleanup && !isCachedPath(imagePath) {
 				_ = os.Remove(imagePath)
 			}
 			return nil, err
 		}
 
-		// Clean up downloaded file if requested
-		if downloadedFile && cleanup {
+		// Clean up downloaded file if requested. Cached assets are left in
+		// place so the next push of the same URL is a cache hit instead of
+		// re-downloading.
+		if downloadedFile && cleanup && !isCachedPath(imagePath) {
 			log.Info().Str("imagePath", imagePath).Msg("Cleaning up downloaded image")
 			_ = os.Remove(imagePath)
 		}
@@ -416,3 +424,38 @@ func (t *ToolClearImage) Implement() server.ToolHandlerFunc {
 func (t *ToolClearImage) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
 	return BuildMCPCallToolRequest(t.Name(), map[string]any{}, action), nil
 }
+
+// ToolClearImageCache implements the clear_image_cache tool call.
+type ToolClearImageCache struct {
+	// Return data fields - these define the structure of data returned by this tool
+	Success bool `json:"success" desc:"Whether the cache was purged successfully"`
+}
+
+func (t *ToolClearImageCache) Name() option.ActionName {
+	return option.ACTION_ClearImageCache
+}
+
+func (t *ToolClearImageCache) Description() string {
+	return "Purge the on-disk cache of images downloaded by push_image via imageUrl. Use this to force the next push_image call to re-download the asset."
+}
+
+func (t *ToolClearImageCache) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{}
+}
+
+func (t *ToolClearImageCache) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := ClearImageCache(); err != nil {
+			return nil, err
+		}
+
+		message := "Successfully cleared the image download cache"
+		returnData := ToolClearImageCache{Success: true}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolClearImageCache) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	return BuildMCPCallToolRequest(t.Name(), map[string]any{}, action), nil
+}
@@ -0,0 +1,180 @@
+package uixt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// ToolListImages implements the list_images tool call.
+type ToolListImages struct {
+	// Return data fields - these define the structure of data returned by this tool
+	Images     []option.ImageInfo `json:"images" desc:"Images found in the device gallery"`
+	TotalCount int                `json:"totalCount" desc:"Total number of images matching the filter, before pagination"`
+}
+
+func (t *ToolListImages) Name() option.ActionName {
+	return option.ACTION_ListImages
+}
+
+func (t *ToolListImages) Description() string {
+	return "List images in the device's gallery (DCIM/Camera on Android, the album populated by push_image on iOS), so a caller can verify a push succeeded or diff gallery contents between test steps."
+}
+
+func (t *ToolListImages) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("platform", mcp.Enum("android", "ios"), mcp.Description("The platform type of device to list images from")),
+		mcp.WithString("serial", mcp.Description("The device serial number or UDID")),
+		mcp.WithNumber("offset", mcp.Description("Number of images to skip, for pagination. Default: 0")),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of images to return. Default: 50")),
+		mcp.WithNumber("since", mcp.Description("Only return images modified after this Unix timestamp")),
+		mcp.WithBoolean("includeThumbnail", mcp.Description("Whether to generate a small JPEG thumbnail for each image, base64-encoded. Default: false")),
+		mcp.WithBoolean("includeHash", mcp.Description("Whether to compute the SHA-256 of each image's contents, the same hash pull_image reports. Requires pulling the full file off the device, like includeThumbnail. Default: false")),
+	}
+}
+
+func (t *ToolListImages) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		driverExt, err := setupXTDriver(ctx, request.GetArguments())
+		if err != nil {
+			return nil, err
+		}
+
+		arguments := request.GetArguments()
+		opts := option.ListImagesOptions{
+			Offset: 0,
+			Limit:  50,
+		}
+		if v, ok := arguments["offset"].(float64); ok && v >= 0 {
+			opts.Offset = int(v)
+		}
+		if v, ok := arguments["limit"].(float64); ok && v > 0 {
+			opts.Limit = int(v)
+		}
+		if v, ok := arguments["since"].(float64); ok && v > 0 {
+			opts.Since = int64(v)
+		}
+		if v, ok := arguments["includeThumbnail"].(bool); ok {
+			opts.IncludeThumbnail = v
+		}
+		if v, ok := arguments["includeHash"].(bool); ok {
+			opts.IncludeHash = v
+		}
+
+		images, total, err := driverExt.IDriver.ListImages(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		message := fmt.Sprintf("Found %d images (returning %d)", total, len(images))
+		returnData := ToolListImages{Images: images, TotalCount: total}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolListImages) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	return BuildMCPCallToolRequest(t.Name(), map[string]any{}, action), nil
+}
+
+// ToolPullImage implements the pull_image tool call.
+type ToolPullImage struct {
+	// Return data fields - these define the structure of data returned by this tool
+	LocalPath string `json:"localPath" desc:"Local path the image was pulled to"`
+	Sha256    string `json:"sha256" desc:"SHA-256 hash of the pulled file contents"`
+}
+
+func (t *ToolPullImage) Name() option.ActionName {
+	return option.ACTION_PullImage
+}
+
+func (t *ToolPullImage) Description() string {
+	return "Pull a specific image off the device's gallery to a local path, identified either by its remote path (from list_images) or its index in the gallery."
+}
+
+func (t *ToolPullImage) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("platform", mcp.Enum("android", "ios"), mcp.Description("The platform type of device to pull the image from")),
+		mcp.WithString("serial", mcp.Description("The device serial number or UDID")),
+		mcp.WithString("path", mcp.Description("Remote path of the image to pull, as returned by list_images")),
+		mcp.WithNumber("index", mcp.Description("Index of the image in the gallery to pull, as an alternative to path")),
+		mcp.WithString("outputPath", mcp.Description("Local path to write the pulled image to. If not specified, a temp file is used.")),
+	}
+}
+
+func (t *ToolPullImage) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		driverExt, err := setupXTDriver(ctx, request.GetArguments())
+		if err != nil {
+			return nil, err
+		}
+
+		arguments := request.GetArguments()
+		remotePath, hasPath := arguments["path"].(string)
+		index, hasIndex := arguments["index"].(float64)
+
+		if (!hasPath || remotePath == "") && !hasIndex {
+			return nil, fmt.Errorf("either path or index is required")
+		}
+		if hasIndex && index < 0 {
+			return nil, fmt.Errorf("index must be non-negative, got %d", int(index))
+		}
+
+		if !hasPath || remotePath == "" {
+			images, _, err := driverExt.IDriver.ListImages(option.ListImagesOptions{Offset: 0, Limit: int(index) + 1})
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve index %d: %v", int(index), err)
+			}
+			if int(index) >= len(images) {
+				return nil, fmt.Errorf("index %d out of range, gallery has %d images", int(index), len(images))
+			}
+			remotePath = images[int(index)].Path
+		}
+
+		outputPath, _ := arguments["outputPath"].(string)
+		if outputPath == "" {
+			tmpFile, err := os.CreateTemp("", "pull-image-*"+filepath.Ext(remotePath))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create temp file: %v", err)
+			}
+			outputPath = tmpFile.Name()
+			tmpFile.Close()
+		}
+
+		if err := driverExt.IDriver.PullImage(remotePath, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to pull image: %v", err)
+		}
+
+		hash, err := sha256File(outputPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash pulled image: %v", err)
+		}
+
+		message := fmt.Sprintf("Successfully pulled %s to %s", remotePath, outputPath)
+		returnData := ToolPullImage{LocalPath: outputPath, Sha256: hash}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolPullImage) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	arguments := map[string]any{}
+	if params, ok := action.Params.(map[string]interface{}); ok {
+		if path, ok := params["path"].(string); ok && path != "" {
+			arguments["path"] = path
+		}
+		if index, ok := params["index"].(float64); ok {
+			arguments["index"] = index
+		}
+		if outputPath, ok := params["outputPath"].(string); ok && outputPath != "" {
+			arguments["outputPath"] = outputPath
+		}
+	}
+	return BuildMCPCallToolRequest(t.Name(), arguments, action), nil
+}
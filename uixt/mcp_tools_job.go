@@ -0,0 +1,205 @@
+package uixt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// ToolStartScreenRecord implements the start_screen_record tool call. Unlike
+// ToolScreenRecord, it returns immediately with a job ID instead of blocking
+// until the recording completes, so the recording can be observed or
+// canceled via get_job_status / cancel_job.
+type ToolStartScreenRecord struct {
+	// Return data fields - these define the structure of data returned by this tool
+	JobID string `json:"jobId" desc:"ID of the started job, used to poll get_job_status or cancel_job"`
+}
+
+func (t *ToolStartScreenRecord) Name() option.ActionName {
+	return option.ACTION_StartScreenRecord
+}
+
+func (t *ToolStartScreenRecord) Description() string {
+	return "Start recording the screen of the mobile device asynchronously and return a job ID immediately. Use get_job_status to poll for completion and cancel_job to stop the recording early."
+}
+
+func (t *ToolStartScreenRecord) Options() []mcp.ToolOption {
+	return (&ToolScreenRecord{}).Options()
+}
+
+func (t *ToolStartScreenRecord) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments := request.GetArguments()
+		driverExt, err := setupXTDriver(ctx, arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		var opts []option.ActionOption
+		if duration, ok := arguments["duration"].(float64); ok && duration > 0 {
+			opts = append(opts, option.WithDuration(duration))
+		}
+		if path, ok := arguments["screenRecordPath"].(string); ok && path != "" {
+			opts = append(opts, option.WithScreenRecordPath(path))
+		}
+		if audio, ok := arguments["screenRecordWithAudio"].(bool); ok && audio {
+			opts = append(opts, option.WithScreenRecordAudio(true))
+		}
+		if scrcpy, ok := arguments["screenRecordWithScrcpy"].(bool); ok && scrcpy {
+			opts = append(opts, option.WithScreenRecordScrcpy(true))
+		}
+
+		jm := GetJobManager()
+		job, jobCtx := jm.NewJob()
+		opts = append(opts, option.WithContext(jobCtx))
+
+		jm.MarkRunning(job)
+		go func() {
+			videoPath, err := driverExt.IDriver.ScreenRecord(opts...)
+			if err != nil {
+				log.Error().Err(err).Str("jobId", job.ID).Msg("async ScreenRecord failed")
+				jm.Finish(job, nil, err)
+				return
+			}
+
+			options := option.NewActionOptions(opts...)
+			method := "adb"
+			if options.ScreenRecordWithScrcpy || options.ScreenRecordWithAudio {
+				method = "scrcpy"
+			}
+			duration := options.Duration
+			if options.ScreenRecordDuration > 0 {
+				duration = options.ScreenRecordDuration
+			}
+
+			jm.Finish(job, &ToolScreenRecord{
+				VideoPath: videoPath,
+				Duration:  duration,
+				Method:    method,
+			}, nil)
+		}()
+
+		message := fmt.Sprintf("Started screen recording job: %s", job.ID)
+		returnData := ToolStartScreenRecord{JobID: job.ID}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolStartScreenRecord) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	return BuildMCPCallToolRequest(t.Name(), map[string]any{}, action), nil
+}
+
+// ToolGetJobStatus implements the get_job_status tool call.
+type ToolGetJobStatus struct {
+	// Return data fields - these define the structure of data returned by this tool
+	JobID  string    `json:"jobId" desc:"ID of the job"`
+	Status JobStatus `json:"status" desc:"Current status of the job: pending, running, succeeded, failed or canceled"`
+	Error  string    `json:"error,omitempty" desc:"Error message if the job failed"`
+	Result any       `json:"result,omitempty" desc:"Structured result of the job, set only when the job succeeded; failed and canceled jobs carry no result"`
+}
+
+func (t *ToolGetJobStatus) Name() option.ActionName {
+	return option.ACTION_GetJobStatus
+}
+
+func (t *ToolGetJobStatus) Description() string {
+	return "Get the status of an async job started by a tool such as start_screen_record. Returns the structured result once the job has succeeded; failed and canceled jobs return no result."
+}
+
+func (t *ToolGetJobStatus) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("jobId", mcp.Required(), mcp.Description("ID of the job to query")),
+	}
+}
+
+func (t *ToolGetJobStatus) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		jobID, ok := request.GetArguments()["jobId"].(string)
+		if !ok || jobID == "" {
+			return nil, fmt.Errorf("jobId is required")
+		}
+
+		job, ok := GetJobManager().Get(jobID)
+		if !ok {
+			return NewMCPErrorResponse("job not found: " + jobID), nil
+		}
+		snapshot := job.Snapshot()
+
+		message := fmt.Sprintf("Job %s is %s", snapshot.ID, snapshot.Status)
+		returnData := ToolGetJobStatus{
+			JobID:  snapshot.ID,
+			Status: snapshot.Status,
+			Error:  snapshot.Error,
+			Result: snapshot.Result,
+		}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolGetJobStatus) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	arguments := map[string]any{}
+	if params, ok := action.Params.(map[string]interface{}); ok {
+		if jobID, ok := params["jobId"].(string); ok && jobID != "" {
+			arguments["jobId"] = jobID
+		}
+	}
+	return BuildMCPCallToolRequest(t.Name(), arguments, action), nil
+}
+
+// ToolCancelJob implements the cancel_job tool call.
+type ToolCancelJob struct {
+	// Return data fields - these define the structure of data returned by this tool
+	JobID  string    `json:"jobId" desc:"ID of the job that was canceled"`
+	Status JobStatus `json:"status" desc:"Status of the job after the cancel request was processed"`
+}
+
+func (t *ToolCancelJob) Name() option.ActionName {
+	return option.ACTION_CancelJob
+}
+
+func (t *ToolCancelJob) Description() string {
+	return "Cancel a running async job, such as an in-progress screen recording started by start_screen_record. Waits for the underlying operation to unwind so any output file is finalized before returning."
+}
+
+func (t *ToolCancelJob) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("jobId", mcp.Required(), mcp.Description("ID of the job to cancel")),
+	}
+}
+
+func (t *ToolCancelJob) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		jobID, ok := request.GetArguments()["jobId"].(string)
+		if !ok || jobID == "" {
+			return nil, fmt.Errorf("jobId is required")
+		}
+
+		job, err := GetJobManager().Cancel(jobID)
+		if err != nil {
+			return NewMCPErrorResponse("failed to cancel job: " + err.Error()), nil
+		}
+		snapshot := job.Snapshot()
+
+		message := fmt.Sprintf("Job %s is now %s", snapshot.ID, snapshot.Status)
+		returnData := ToolCancelJob{JobID: snapshot.ID, Status: snapshot.Status}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolCancelJob) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	arguments := map[string]any{}
+	if params, ok := action.Params.(map[string]interface{}); ok {
+		if jobID, ok := params["jobId"].(string); ok && jobID != "" {
+			arguments["jobId"] = jobID
+		}
+	}
+	return BuildMCPCallToolRequest(t.Name(), arguments, action), nil
+}
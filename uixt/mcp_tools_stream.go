@@ -0,0 +1,357 @@
+package uixt
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+
+	"github.com/httprunner/httprunner/v5/uixt/option"
+)
+
+// streamRingSize bounds how many undelivered frames a slow consumer may fall
+// behind by before the producer starts dropping frames for it, rather than
+// blocking.
+const streamRingSize = 4
+
+// streamHub fans a single device's frames out to any number of concurrent
+// HTTP consumers. Slow consumers have frames dropped rather than stalling
+// the producer.
+type streamHub struct {
+	serial string
+
+	mu        sync.Mutex
+	consumers map[chan []byte]struct{}
+
+	snapshot []byte
+
+	cancel context.CancelFunc
+}
+
+func newStreamHub(serial string, cancel context.CancelFunc) *streamHub {
+	return &streamHub{
+		serial:    serial,
+		consumers: make(map[chan []byte]struct{}),
+		cancel:    cancel,
+	}
+}
+
+func (h *streamHub) subscribe() chan []byte {
+	ch := make(chan []byte, streamRingSize)
+	h.mu.Lock()
+	h.consumers[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *streamHub) unsubscribe(ch chan []byte) {
+	h.mu.Lock()
+	delete(h.consumers, ch)
+	h.mu.Unlock()
+}
+
+// publish broadcasts a frame to every subscriber. A subscriber whose buffer
+// is full is skipped for this frame instead of blocking the producer.
+func (h *streamHub) publish(frame []byte) {
+	h.mu.Lock()
+	h.snapshot = frame
+	for ch := range h.consumers {
+		select {
+		case ch <- frame:
+		default:
+			// slow consumer, drop the frame for it
+		}
+	}
+	h.mu.Unlock()
+}
+
+func (h *streamHub) latestSnapshot() []byte {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.snapshot
+}
+
+func (h *streamHub) close() {
+	h.cancel()
+	h.mu.Lock()
+	for ch := range h.consumers {
+		close(ch)
+		delete(h.consumers, ch)
+	}
+	h.mu.Unlock()
+}
+
+// streamServer is the process-wide HTTP server that exposes every active
+// device's streamHub. It is started lazily, once per process, on the first
+// call to start_screen_stream.
+type streamServer struct {
+	mu   sync.Mutex
+	hubs map[string]*streamHub
+
+	addr string
+}
+
+var (
+	globalStreamServer     *streamServer
+	globalStreamServerOnce sync.Once
+)
+
+func getStreamServer() (*streamServer, error) {
+	var startErr error
+	globalStreamServerOnce.Do(func() {
+		s := &streamServer{hubs: make(map[string]*streamHub)}
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			startErr = err
+			return
+		}
+		s.addr = listener.Addr().String()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/stream/", s.handleStream)
+		mux.HandleFunc("/snapshot/", s.handleSnapshot)
+
+		go func() {
+			if err := http.Serve(listener, mux); err != nil {
+				log.Error().Err(err).Msg("screen stream HTTP server stopped")
+			}
+		}()
+
+		globalStreamServer = s
+	})
+	if startErr != nil {
+		return nil, startErr
+	}
+	return globalStreamServer, nil
+}
+
+// reserve atomically claims serial for a not-yet-constructed stream, so two
+// concurrent start_screen_stream calls for the same device can't both pass
+// the "already running" check before either registers its hub. It returns
+// false if serial is already reserved or registered.
+func (s *streamServer) reserve(serial string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.hubs[serial]; exists {
+		return false
+	}
+	s.hubs[serial] = nil
+	return true
+}
+
+// register attaches the constructed hub to a serial previously claimed by
+// reserve.
+func (s *streamServer) register(serial string, hub *streamHub) {
+	s.mu.Lock()
+	s.hubs[serial] = hub
+	s.mu.Unlock()
+}
+
+func (s *streamServer) unregister(serial string) {
+	s.mu.Lock()
+	delete(s.hubs, serial)
+	s.mu.Unlock()
+}
+
+// get returns the hub registered for serial. A serial that is only reserved
+// (hub not yet constructed) is reported as not found.
+func (s *streamServer) get(serial string) (*streamHub, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hub, ok := s.hubs[serial]
+	if !ok || hub == nil {
+		return nil, false
+	}
+	return hub, true
+}
+
+// handleStream serves the MJPEG multipart stream for a registered serial
+// only; unknown serials are rejected so the endpoint cannot be used to
+// traverse the filesystem.
+func (s *streamServer) handleStream(w http.ResponseWriter, r *http.Request) {
+	serial := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/stream/"), ".mjpeg")
+	hub, ok := s.get(serial)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	const boundary = "httprunnerframe"
+	w.Header().Set("Content-Type", fmt.Sprintf("multipart/x-mixed-replace; boundary=%s", boundary))
+	w.Header().Set("Cache-Control", "no-cache")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := hub.subscribe()
+	defer hub.unsubscribe(ch)
+
+	for {
+		select {
+		case frame, ok := <-ch:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "--%s\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n", boundary, len(frame))
+			w.Write(frame)
+			fmt.Fprint(w, "\r\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleSnapshot serves a single current JPEG frame for a registered serial.
+func (s *streamServer) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	serial := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/snapshot/"), ".jpeg")
+	hub, ok := s.get(serial)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	frame := hub.latestSnapshot()
+	if frame == nil {
+		http.Error(w, "no frame available yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Write(frame)
+}
+
+// ToolScreenStream implements the start_screen_stream tool call.
+type ToolScreenStream struct {
+	// Return data fields - these define the structure of data returned by this tool
+	JobID       string `json:"jobId" desc:"ID of the streaming job, pass to stop_screen_stream to tear it down"`
+	StreamURL   string `json:"streamUrl" desc:"MJPEG stream URL for real-time observation"`
+	SnapshotURL string `json:"snapshotUrl" desc:"URL returning a single current JPEG frame"`
+}
+
+func (t *ToolScreenStream) Name() option.ActionName {
+	return option.ACTION_ScreenStream
+}
+
+func (t *ToolScreenStream) Description() string {
+	return "Start a live MJPEG screen stream for a device, served over a local HTTP endpoint so an agent can observe on-screen changes in real time instead of waiting for a recorded video file. Use stop_screen_stream to tear it down."
+}
+
+func (t *ToolScreenStream) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("platform", mcp.Enum("android", "ios"), mcp.Description("The platform type of device to stream")),
+		mcp.WithString("serial", mcp.Description("The device serial number or UDID")),
+		mcp.WithNumber("fps", mcp.Description("Target frames per second to capture. Default: 10")),
+		mcp.WithNumber("maxWidth", mcp.Description("Maximum frame width in pixels; frames are scaled down to this width. Default: device width")),
+	}
+}
+
+func (t *ToolScreenStream) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments := request.GetArguments()
+		driverExt, err := setupXTDriver(ctx, arguments)
+		if err != nil {
+			return nil, err
+		}
+		serial := driverExt.IDriver.GetDevice().UUID()
+
+		fps := 10
+		if v, ok := arguments["fps"].(float64); ok && v > 0 {
+			fps = int(v)
+		}
+		maxWidth := 0
+		if v, ok := arguments["maxWidth"].(float64); ok && v > 0 {
+			maxWidth = int(v)
+		}
+
+		streamSrv, err := getStreamServer()
+		if err != nil {
+			return nil, fmt.Errorf("failed to start screen stream server: %v", err)
+		}
+
+		if !streamSrv.reserve(serial) {
+			return nil, fmt.Errorf("a screen stream is already running for device %s", serial)
+		}
+
+		jm := GetJobManager()
+		job, jobCtx := jm.NewJob()
+		streamCtx, cancel := context.WithCancel(jobCtx)
+		hub := newStreamHub(serial, cancel)
+		streamSrv.register(serial, hub)
+
+		jm.MarkRunning(job)
+		go func() {
+			err := driverExt.IDriver.StreamScreen(streamCtx, option.WithFPS(fps), option.WithMaxWidth(maxWidth), option.WithFrameHandler(hub.publish))
+			streamSrv.unregister(serial)
+			hub.close()
+			jm.Finish(job, nil, err)
+		}()
+
+		streamURL := fmt.Sprintf("http://%s/stream/%s.mjpeg", streamSrv.addr, serial)
+		snapshotURL := fmt.Sprintf("http://%s/snapshot/%s.jpeg", streamSrv.addr, serial)
+
+		message := fmt.Sprintf("Started screen stream for device %s at %s", serial, streamURL)
+		returnData := ToolScreenStream{JobID: job.ID, StreamURL: streamURL, SnapshotURL: snapshotURL}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolScreenStream) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	return BuildMCPCallToolRequest(t.Name(), map[string]any{}, action), nil
+}
+
+// ToolStopScreenStream implements the stop_screen_stream tool call.
+type ToolStopScreenStream struct {
+	// Return data fields - these define the structure of data returned by this tool
+	Success bool `json:"success" desc:"Whether the stream was torn down successfully"`
+}
+
+func (t *ToolStopScreenStream) Name() option.ActionName {
+	return option.ACTION_StopScreenStream
+}
+
+func (t *ToolStopScreenStream) Description() string {
+	return "Stop a screen stream started by start_screen_stream and close its HTTP endpoint."
+}
+
+func (t *ToolStopScreenStream) Options() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("jobId", mcp.Required(), mcp.Description("Job ID returned by start_screen_stream")),
+	}
+}
+
+func (t *ToolStopScreenStream) Implement() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		jobID, ok := request.GetArguments()["jobId"].(string)
+		if !ok || jobID == "" {
+			return nil, fmt.Errorf("jobId is required")
+		}
+
+		if _, err := GetJobManager().Cancel(jobID); err != nil {
+			return NewMCPErrorResponse("failed to stop screen stream: " + err.Error()), nil
+		}
+
+		message := "Successfully stopped screen stream"
+		returnData := ToolStopScreenStream{Success: true}
+
+		return NewMCPSuccessResponse(message, &returnData), nil
+	}
+}
+
+func (t *ToolStopScreenStream) ConvertActionToCallToolRequest(action option.MobileAction) (mcp.CallToolRequest, error) {
+	arguments := map[string]any{}
+	if params, ok := action.Params.(map[string]interface{}); ok {
+		if jobID, ok := params["jobId"].(string); ok && jobID != "" {
+			arguments["jobId"] = jobID
+		}
+	}
+	return BuildMCPCallToolRequest(t.Name(), arguments, action), nil
+}
@@ -0,0 +1,27 @@
+package option
+
+// ActionName identifies an MCP tool / mobile action.
+type ActionName string
+
+const (
+	ACTION_ListAvailableDevices ActionName = "list_available_devices"
+	ACTION_SelectDevice         ActionName = "select_device"
+	ACTION_ScreenRecord         ActionName = "screenrecord"
+	ACTION_PushImage            ActionName = "push_image"
+	ACTION_ClearImage           ActionName = "clear_image"
+
+	ACTION_StartScreenRecord ActionName = "start_screen_record"
+	ACTION_GetJobStatus      ActionName = "get_job_status"
+	ACTION_CancelJob         ActionName = "cancel_job"
+
+	ACTION_PushImageBatch    ActionName = "push_image_batch"
+	ACTION_ScreenRecordBatch ActionName = "screen_record_batch"
+
+	ACTION_ClearImageCache ActionName = "clear_image_cache"
+
+	ACTION_ScreenStream     ActionName = "start_screen_stream"
+	ACTION_StopScreenStream ActionName = "stop_screen_stream"
+
+	ACTION_ListImages ActionName = "list_images"
+	ACTION_PullImage  ActionName = "pull_image"
+)
@@ -0,0 +1,23 @@
+package option
+
+// ListImagesOptions controls pagination and filtering for IDriver.ListImages.
+type ListImagesOptions struct {
+	Offset           int
+	Limit            int
+	Since            int64 // only return images with mtime after this Unix timestamp
+	IncludeThumbnail bool
+	// IncludeHash requests the SHA-256 of each image's contents, the same
+	// hash PullImage reports. It is opt-in because, like IncludeThumbnail,
+	// computing it requires pulling the full file off the device, not just
+	// stat'ing it.
+	IncludeHash bool
+}
+
+// ImageInfo describes a single image found in a device's gallery.
+type ImageInfo struct {
+	Path            string `json:"path"`
+	SizeBytes       int64  `json:"sizeBytes"`
+	MTime           int64  `json:"mtime"`
+	Sha256          string `json:"sha256"`
+	ThumbnailBase64 string `json:"thumbnailBase64,omitempty"`
+}
@@ -0,0 +1,85 @@
+package option
+
+import "context"
+
+// MobileAction is the declarative representation of a single step in a
+// HttpRunner test case that maps onto an MCP tool call.
+type MobileAction struct {
+	Method        ActionName
+	Params        interface{}
+	ActionOptions ActionOptions
+}
+
+// ActionOption mutates ActionOptions; passed variadically to driver methods
+// such as ScreenRecord so callers can opt into behavior without changing the
+// method signature.
+type ActionOption func(*ActionOptions)
+
+// ActionOptions collects every optional knob a driver action can be called
+// with. Not every field is relevant to every action.
+type ActionOptions struct {
+	Context context.Context
+
+	Duration float64
+
+	ScreenRecordPath       string
+	ScreenRecordDuration   float64
+	ScreenRecordWithAudio  bool
+	ScreenRecordWithScrcpy bool
+
+	FPS          int
+	MaxWidth     int
+	FrameHandler func(frame []byte)
+
+	Custom map[string]interface{}
+}
+
+// NewActionOptions applies opts to a fresh ActionOptions and returns it.
+func NewActionOptions(opts ...ActionOption) ActionOptions {
+	var options ActionOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func WithContext(ctx context.Context) ActionOption {
+	return func(o *ActionOptions) { o.Context = ctx }
+}
+
+func WithDuration(duration float64) ActionOption {
+	return func(o *ActionOptions) { o.Duration = duration }
+}
+
+func WithScreenRecordPath(path string) ActionOption {
+	return func(o *ActionOptions) { o.ScreenRecordPath = path }
+}
+
+func WithScreenRecordAudio(enabled bool) ActionOption {
+	return func(o *ActionOptions) { o.ScreenRecordWithAudio = enabled }
+}
+
+func WithScreenRecordScrcpy(enabled bool) ActionOption {
+	return func(o *ActionOptions) { o.ScreenRecordWithScrcpy = enabled }
+}
+
+func WithFPS(fps int) ActionOption {
+	return func(o *ActionOptions) { o.FPS = fps }
+}
+
+func WithMaxWidth(maxWidth int) ActionOption {
+	return func(o *ActionOptions) { o.MaxWidth = maxWidth }
+}
+
+func WithFrameHandler(handler func(frame []byte)) ActionOption {
+	return func(o *ActionOptions) { o.FrameHandler = handler }
+}
+
+func WithUDID(udid string) ActionOption {
+	return func(o *ActionOptions) {
+		if o.Custom == nil {
+			o.Custom = make(map[string]interface{})
+		}
+		o.Custom["udid"] = udid
+	}
+}
@@ -0,0 +1,192 @@
+package uixt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// urlCacheDir is where downloaded push_image assets are cached, keyed by the
+// SHA-256 of their source URL. It can be overridden for tests.
+var urlCacheDir = filepath.Join(os.TempDir(), "httprunner-url-cache")
+
+// urlCacheSidecar is the JSON metadata stored alongside each cached file.
+type urlCacheSidecar struct {
+	URL         string    `json:"url"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	MTime       time.Time `json:"mtime"`
+}
+
+// urlCacheGroup collapses concurrent fetches of the same URL (e.g. from the
+// batch push tool) into a single download.
+var urlCacheGroup singleflight.Group
+
+// urlCacheKey returns the hex-encoded SHA-256 digest of url, used as the
+// cache file's basename.
+func urlCacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// sidecarPath returns the metadata file path for a cached asset.
+func sidecarPath(path string) string {
+	return path + ".json"
+}
+
+// isCachedPath reports whether path lives under the url cache directory, so
+// cleanup logic can avoid deleting a cached asset after its first use.
+func isCachedPath(path string) bool {
+	rel, err := filepath.Rel(urlCacheDir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// GetOrDownloadImage returns the local path of imageUrl, either from the
+// on-disk cache or by downloading it. useCache false bypasses the cache
+// entirely (always download). ttl, if positive, invalidates a cache entry whose
+// sidecar mtime is older than ttl.
+func GetOrDownloadImage(imageUrl string, useCache bool, ttl time.Duration) (string, error) {
+	if !useCache {
+		path, _, err := downloadAndDetect(imageUrl)
+		return path, err
+	}
+
+	key := urlCacheKey(imageUrl)
+	cachedGlob, err := filepath.Glob(filepath.Join(urlCacheDir, key+".*"))
+	if err == nil {
+		for _, candidate := range cachedGlob {
+			if filepath.Ext(candidate) == ".json" {
+				continue
+			}
+			if sidecar, ok := readSidecar(candidate); ok {
+				if ttl <= 0 || time.Since(sidecar.MTime) < ttl {
+					// Bump both the file mtime (for LRU) and the sidecar's
+					// mtime (since cacheTTL is measured against the
+					// sidecar, not the OS file) so a TTL is anchored to
+					// last access rather than first download.
+					now := time.Now()
+					_ = os.Chtimes(candidate, now, now)
+					sidecar.MTime = now
+					if data, err := json.Marshal(sidecar); err == nil {
+						_ = os.WriteFile(sidecarPath(candidate), data, 0o644)
+					}
+					log.Info().Str("imageUrl", imageUrl).Str("path", candidate).Msg("url cache hit")
+					return candidate, nil
+				}
+			}
+		}
+	}
+
+	// singleflight so N simultaneous pushes of one URL trigger one download.
+	v, err, _ := urlCacheGroup.Do(key, func() (interface{}, error) {
+		return cacheDownload(imageUrl, key)
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// cacheDownload fetches imageUrl to a temp file, detects its extension, and
+// renames it into the cache directory under key (the URL's hash) alongside a
+// JSON sidecar.
+func cacheDownload(imageUrl, key string) (string, error) {
+	downloadedPath, contentType, err := downloadAndDetect(imageUrl)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(urlCacheDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create url cache dir: %v", err)
+	}
+
+	ext := filepath.Ext(downloadedPath)
+	cachedPath := filepath.Join(urlCacheDir, key+ext)
+
+	info, err := os.Stat(downloadedPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat downloaded file: %v", err)
+	}
+
+	if err := os.Rename(downloadedPath, cachedPath); err != nil {
+		return "", fmt.Errorf("failed to move downloaded file into cache: %v", err)
+	}
+
+	sidecar := urlCacheSidecar{
+		URL:         imageUrl,
+		ContentType: contentType,
+		Size:        info.Size(),
+		MTime:       time.Now(),
+	}
+	if data, err := json.Marshal(&sidecar); err == nil {
+		_ = os.WriteFile(sidecarPath(cachedPath), data, 0o644)
+	}
+
+	log.Info().Str("imageUrl", imageUrl).Str("path", cachedPath).Msg("url cache miss, downloaded and cached")
+	return cachedPath, nil
+}
+
+// downloadURLFunc fetches imageUrl to a local temp file path. It is a var so
+// tests can substitute a fake implementation instead of hitting the network.
+var downloadURLFunc = DownloadFileByUrl
+
+// downloadAndDetect downloads imageUrl to a temp file, renames it with the
+// detected image extension, and returns the sniffed content type.
+func downloadAndDetect(imageUrl string) (string, string, error) {
+	downloadedPath, err := downloadURLFunc(imageUrl)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download image from URL: %v", err)
+	}
+
+	contentType, err := detectFileContentType(downloadedPath)
+	if err != nil {
+		log.Warn().Err(err).Str("path", downloadedPath).Msg("Failed to detect content type")
+	}
+
+	renamedPath, err := DetectAndRenameImageFile(downloadedPath)
+	if err != nil {
+		log.Warn().Err(err).Str("path", downloadedPath).Msg("Failed to detect image type or rename file, using original file")
+		return downloadedPath, contentType, nil
+	}
+	return renamedPath, contentType, nil
+}
+
+func readSidecar(path string) (*urlCacheSidecar, bool) {
+	data, err := os.ReadFile(sidecarPath(path))
+	if err != nil {
+		return nil, false
+	}
+	var sidecar urlCacheSidecar
+	if err := json.Unmarshal(data, &sidecar); err != nil {
+		return nil, false
+	}
+	return &sidecar, true
+}
+
+// ClearImageCache removes every entry from the on-disk url cache.
+func ClearImageCache() error {
+	entries, err := os.ReadDir(urlCacheDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.Remove(filepath.Join(urlCacheDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
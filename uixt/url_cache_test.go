@@ -0,0 +1,129 @@
+package uixt
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func withFakeDownloader(t *testing.T, downloads *int32) {
+	t.Helper()
+	orig := downloadURLFunc
+	dir := t.TempDir()
+	downloadURLFunc = func(url string) (string, error) {
+		atomic.AddInt32(downloads, 1)
+		f, err := os.CreateTemp(dir, "download-*")
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		// minimal JPEG magic bytes so DetectAndRenameImageFile succeeds
+		f.Write([]byte{0xFF, 0xD8, 0xFF, 0xE0})
+		return f.Name(), nil
+	}
+	t.Cleanup(func() { downloadURLFunc = orig })
+}
+
+func withTempCacheDir(t *testing.T) {
+	t.Helper()
+	orig := urlCacheDir
+	urlCacheDir = t.TempDir()
+	t.Cleanup(func() { urlCacheDir = orig })
+}
+
+func TestIsCachedPath(t *testing.T) {
+	withTempCacheDir(t)
+
+	inside := filepath.Join(urlCacheDir, "abc.jpg")
+	if !isCachedPath(inside) {
+		t.Fatalf("expected %s to be recognized as cached", inside)
+	}
+
+	outside := filepath.Join(t.TempDir(), "abc.jpg")
+	if isCachedPath(outside) {
+		t.Fatalf("expected %s to not be recognized as cached", outside)
+	}
+}
+
+func TestGetOrDownloadImageCachesByURL(t *testing.T) {
+	withTempCacheDir(t)
+	var downloads int32
+	withFakeDownloader(t, &downloads)
+
+	path1, err := GetOrDownloadImage("http://example.com/a.jpg", true, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	path2, err := GetOrDownloadImage("http://example.com/a.jpg", true, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path1 != path2 {
+		t.Fatalf("expected cache hit to reuse path, got %s and %s", path1, path2)
+	}
+	if got := atomic.LoadInt32(&downloads); got != 1 {
+		t.Fatalf("expected exactly 1 download, got %d", got)
+	}
+}
+
+func TestGetOrDownloadImageUseCacheFalseAlwaysDownloads(t *testing.T) {
+	withTempCacheDir(t)
+	var downloads int32
+	withFakeDownloader(t, &downloads)
+
+	if _, err := GetOrDownloadImage("http://example.com/b.jpg", false, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := GetOrDownloadImage("http://example.com/b.jpg", false, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&downloads); got != 2 {
+		t.Fatalf("expected 2 downloads with useCache=false, got %d", got)
+	}
+}
+
+func TestGetOrDownloadImageTTLExpiry(t *testing.T) {
+	withTempCacheDir(t)
+	var downloads int32
+	withFakeDownloader(t, &downloads)
+
+	if _, err := GetOrDownloadImage("http://example.com/c.jpg", true, 20*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := GetOrDownloadImage("http://example.com/c.jpg", true, 20*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&downloads); got != 2 {
+		t.Fatalf("expected cache entry to expire and re-download, got %d downloads", got)
+	}
+}
+
+func TestGetOrDownloadImageConcurrentSingleflight(t *testing.T) {
+	withTempCacheDir(t)
+	var downloads int32
+	withFakeDownloader(t, &downloads)
+
+	const n = 10
+	errCh := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := GetOrDownloadImage("http://example.com/d.jpg", true, 0)
+			errCh <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		if err := <-errCh; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&downloads); got != 1 {
+		t.Fatalf("expected singleflight to collapse concurrent downloads to 1, got %d", got)
+	}
+}